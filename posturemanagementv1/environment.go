@@ -0,0 +1,253 @@
+/**
+ * (C) Copyright IBM Corp. 2021.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package posturemanagementv1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+// Environment identifies the cloud that a scope targets.
+type Environment string
+
+const (
+	// EnvironmentIBM targets IBM Cloud resources.
+	EnvironmentIBM Environment = "ibm"
+	// EnvironmentAWS targets an Amazon Web Services account.
+	EnvironmentAWS Environment = "aws"
+	// EnvironmentAzure targets a Microsoft Azure subscription.
+	EnvironmentAzure Environment = "azure"
+	// EnvironmentGCP targets a Google Cloud Platform project.
+	EnvironmentGCP Environment = "gcp"
+)
+
+// SupportedEnvironments returns the cloud environments that scopes can target.
+func (postureManagement *PostureManagementV1) SupportedEnvironments() []Environment {
+	return []Environment{EnvironmentIBM, EnvironmentAWS, EnvironmentAzure, EnvironmentGCP}
+}
+
+// NewAwsScopeOptions instantiates CreateScopeOptions for a scope that targets an AWS account,
+// pre-populating the environment and the AWS account ID that the credential must grant access to.
+// CreateScopeWithContext rejects the result if credentialID or awsAccountID is empty, since both are
+// required for a non-ibm environment.
+func (postureManagement *PostureManagementV1) NewAwsScopeOptions(accountID string, credentialID string, awsAccountID string) *CreateScopeOptions {
+	options := postureManagement.NewCreateScopeOptions(accountID)
+	options.SetCredentialID(credentialID)
+	options.SetEnvironment(EnvironmentAWS)
+	options.SetCloudAccountID(awsAccountID)
+	return options
+}
+
+// NewAzureScopeOptions instantiates CreateScopeOptions for a scope that targets an Azure subscription,
+// pre-populating the environment and the subscription ID that the credential must grant access to.
+// CreateScopeWithContext rejects the result if credentialID or subscriptionID is empty, since both are
+// required for a non-ibm environment.
+func (postureManagement *PostureManagementV1) NewAzureScopeOptions(accountID string, credentialID string, subscriptionID string) *CreateScopeOptions {
+	options := postureManagement.NewCreateScopeOptions(accountID)
+	options.SetCredentialID(credentialID)
+	options.SetEnvironment(EnvironmentAzure)
+	options.SetCloudAccountID(subscriptionID)
+	return options
+}
+
+// NewGcpScopeOptions instantiates CreateScopeOptions for a scope that targets a GCP project,
+// pre-populating the environment and the project ID that the credential must grant access to.
+// CreateScopeWithContext rejects the result if credentialID or projectID is empty, since both are
+// required for a non-ibm environment.
+func (postureManagement *PostureManagementV1) NewGcpScopeOptions(accountID string, credentialID string, projectID string) *CreateScopeOptions {
+	options := postureManagement.NewCreateScopeOptions(accountID)
+	options.SetCredentialID(credentialID)
+	options.SetEnvironment(EnvironmentGCP)
+	options.SetCloudAccountID(projectID)
+	return options
+}
+
+const (
+	awsMetadataTokenURL   = "http://169.254.169.254/latest/api/token"
+	awsMetadataRoleURL    = "http://169.254.169.254/latest/meta-data/iam/security-credentials/"
+	azureMetadataTokenURL = "http://169.254.169.254/metadata/identity/oauth2/token?api-version=2018-02-01&resource=https://management.azure.com/"
+	gcpMetadataTokenURL   = "http://metadata.google.internal/computeMetadata/v1/instance/service-accounts/default/token"
+)
+
+// EnvironmentCredentialResolver is a CredentialSupplier that, when UseInstanceMetadata is enabled,
+// transparently discovers credentials from the ambient cloud environment instead of requiring the
+// caller to supply credential bytes directly: EC2 IMDSv2 for AWS, the Azure Instance Metadata Service
+// for Azure, and the GCP metadata server for GCP. It synthesizes a credential JSON document from the
+// discovered metadata at CredentialJSON time and uploads it the same way a file-backed credential
+// would be, so the fetched secret never touches disk.
+type EnvironmentCredentialResolver struct {
+	// Environment is the cloud to discover credentials from.
+	Environment Environment
+
+	// UseInstanceMetadata must be set to true to enable ambient credential discovery.
+	UseInstanceMetadata bool
+
+	// Client performs the metadata requests. Defaults to http.DefaultClient.
+	Client *http.Client
+}
+
+// NewEnvironmentCredentialResolver constructs an EnvironmentCredentialResolver for the given environment.
+func NewEnvironmentCredentialResolver(environment Environment) *EnvironmentCredentialResolver {
+	return &EnvironmentCredentialResolver{
+		Environment: environment,
+	}
+}
+
+// SetUseInstanceMetadata : Allow user to set UseInstanceMetadata.
+func (resolver *EnvironmentCredentialResolver) SetUseInstanceMetadata(useInstanceMetadata bool) *EnvironmentCredentialResolver {
+	resolver.UseInstanceMetadata = useInstanceMetadata
+	return resolver
+}
+
+// CredentialJSON implements CredentialSupplier by discovering credentials from the ambient instance
+// metadata service for resolver.Environment.
+func (resolver *EnvironmentCredentialResolver) CredentialJSON(ctx context.Context) ([]byte, error) {
+	if !resolver.UseInstanceMetadata {
+		return nil, fmt.Errorf("EnvironmentCredentialResolver requires UseInstanceMetadata to be enabled")
+	}
+
+	switch resolver.Environment {
+	case EnvironmentAWS:
+		return resolver.awsInstanceMetadataCredential(ctx)
+	case EnvironmentAzure:
+		return resolver.azureInstanceMetadataCredential(ctx)
+	case EnvironmentGCP:
+		return resolver.gcpInstanceMetadataCredential(ctx)
+	default:
+		return nil, fmt.Errorf("instance metadata discovery is not supported for environment %q", resolver.Environment)
+	}
+}
+
+// PEM implements CredentialSupplier. Instance-metadata-discovered credentials do not require PEM
+// key material.
+func (*EnvironmentCredentialResolver) PEM(ctx context.Context) ([]byte, error) {
+	return nil, nil
+}
+
+func (resolver *EnvironmentCredentialResolver) client() *http.Client {
+	if resolver.Client != nil {
+		return resolver.Client
+	}
+	return http.DefaultClient
+}
+
+// awsInstanceMetadataCredential fetches the instance role's temporary credentials from EC2 IMDSv2.
+func (resolver *EnvironmentCredentialResolver) awsInstanceMetadataCredential(ctx context.Context) ([]byte, error) {
+	tokenRequest, err := http.NewRequestWithContext(ctx, http.MethodPut, awsMetadataTokenURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	tokenRequest.Header.Set("X-aws-ec2-metadata-token-ttl-seconds", "21600")
+
+	token, err := resolver.doMetadataRequest(tokenRequest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch EC2 IMDSv2 token: %s", err)
+	}
+
+	roleRequest, err := http.NewRequestWithContext(ctx, http.MethodGet, awsMetadataRoleURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	roleRequest.Header.Set("X-aws-ec2-metadata-token", string(token))
+
+	role, err := resolver.doMetadataRequest(roleRequest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover the EC2 instance role: %s", err)
+	}
+
+	credentialsRequest, err := http.NewRequestWithContext(ctx, http.MethodGet, awsMetadataRoleURL+string(role), nil)
+	if err != nil {
+		return nil, err
+	}
+	credentialsRequest.Header.Set("X-aws-ec2-metadata-token", string(token))
+
+	credential, err := resolver.doMetadataRequest(credentialsRequest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch EC2 instance role credentials: %s", err)
+	}
+
+	return wrapDiscoveredCredential(EnvironmentAWS, credential)
+}
+
+// azureInstanceMetadataCredential fetches a managed-identity access token from the Azure IMDS.
+func (resolver *EnvironmentCredentialResolver) azureInstanceMetadataCredential(ctx context.Context) ([]byte, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, azureMetadataTokenURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Metadata", "true")
+
+	credential, err := resolver.doMetadataRequest(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch an Azure managed-identity token: %s", err)
+	}
+
+	return wrapDiscoveredCredential(EnvironmentAzure, credential)
+}
+
+// gcpInstanceMetadataCredential fetches a service-account access token from the GCP metadata server.
+func (resolver *EnvironmentCredentialResolver) gcpInstanceMetadataCredential(ctx context.Context) ([]byte, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, gcpMetadataTokenURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Metadata-Flavor", "Google")
+
+	credential, err := resolver.doMetadataRequest(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch a GCP service-account token: %s", err)
+	}
+
+	return wrapDiscoveredCredential(EnvironmentGCP, credential)
+}
+
+func (resolver *EnvironmentCredentialResolver) doMetadataRequest(request *http.Request) ([]byte, error) {
+	response, err := resolver.client().Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("metadata request to %s returned status %d", request.URL, response.StatusCode)
+	}
+
+	return body, nil
+}
+
+// wrapDiscoveredCredential synthesizes the credential JSON document that CreateCredential expects from
+// the raw bytes returned by a cloud's instance metadata service.
+func wrapDiscoveredCredential(environment Environment, discovered []byte) ([]byte, error) {
+	var metadata interface{}
+	if err := json.Unmarshal(discovered, &metadata); err != nil {
+		metadata = string(discovered)
+	}
+
+	return json.Marshal(map[string]interface{}{
+		"environment_type": environment,
+		"source":           "instance_metadata",
+		"credential":       metadata,
+	})
+}
@@ -0,0 +1,238 @@
+/**
+ * (C) Copyright IBM Corp. 2021.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package middleware provides http.RoundTripper wrappers for
+// PostureManagementV1Options.HTTPMiddleware: transaction-id propagation, retry-with-backoff, and
+// client-side rate limiting. Each middleware is a plain func(http.RoundTripper) http.RoundTripper, so
+// they compose with any other RoundTripper and are independently testable against an httptest.Server.
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/time/rate"
+)
+
+// roundTripperFunc adapts a function to the http.RoundTripper interface.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Chain composes middlewares around base in the order given: the first middleware is outermost, so it
+// sees the request first and the response last. A nil base defaults to http.DefaultTransport.
+func Chain(base http.RoundTripper, middlewares ...func(http.RoundTripper) http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		base = middlewares[i](base)
+	}
+	return base
+}
+
+type transactionIDContextKey struct{}
+
+// transactionIDHolder is stored in the request context by WithTransactionIDCapture so that the
+// TransactionID middleware has somewhere to record the server-echoed value for the caller to read back
+// after the round trip completes.
+type transactionIDHolder struct {
+	mutex sync.Mutex
+	id    string
+}
+
+// WithTransactionIDCapture returns a context that the TransactionID middleware will populate with the
+// transaction ID it sent (or the server echoed back). Read it after the request completes with
+// TransactionIDFromContext.
+func WithTransactionIDCapture(ctx context.Context) context.Context {
+	return context.WithValue(ctx, transactionIDContextKey{}, &transactionIDHolder{})
+}
+
+// TransactionIDFromContext returns the transaction ID recorded by the TransactionID middleware for a
+// request made with a context derived from WithTransactionIDCapture.
+func TransactionIDFromContext(ctx context.Context) (string, bool) {
+	holder, ok := ctx.Value(transactionIDContextKey{}).(*transactionIDHolder)
+	if !ok {
+		return "", false
+	}
+	holder.mutex.Lock()
+	defer holder.mutex.Unlock()
+	return holder.id, holder.id != ""
+}
+
+// TransactionID returns middleware that injects a transaction-id header on the outgoing request if one
+// is not already present, and records the value the server echoed back (falling back to the value it
+// sent) into the request context for retrieval via TransactionIDFromContext.
+func TransactionID() func(http.RoundTripper) http.RoundTripper {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			id := req.Header.Get("transaction-id")
+			if id == "" {
+				id = uuid.New().String()
+				req.Header.Set("transaction-id", id)
+			}
+
+			resp, err := next.RoundTrip(req)
+
+			recorded := id
+			if resp != nil {
+				if echoed := resp.Header.Get("transaction-id"); echoed != "" {
+					recorded = echoed
+				}
+			}
+			if holder, ok := req.Context().Value(transactionIDContextKey{}).(*transactionIDHolder); ok {
+				holder.mutex.Lock()
+				holder.id = recorded
+				holder.mutex.Unlock()
+			}
+
+			return resp, err
+		})
+	}
+}
+
+// RetryPolicy configures the Retry middleware.
+type RetryPolicy struct {
+	// MaxRetries is the number of retry attempts after the initial request.
+	MaxRetries int
+
+	// BaseDelay is the backoff before the first retry; it doubles after every subsequent attempt.
+	BaseDelay time.Duration
+
+	// MaxDelay caps the backoff between retries.
+	MaxDelay time.Duration
+}
+
+// DefaultRetryPolicy retries up to 3 times, starting at a 250ms backoff and doubling up to 4s.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries: 3,
+	BaseDelay:  250 * time.Millisecond,
+	MaxDelay:   4 * time.Second,
+}
+
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	default:
+		return false
+	}
+}
+
+func isRetryableStatus(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode >= http.StatusInternalServerError
+}
+
+// retryAfterDelay parses a Retry-After header, which RFC 7231 allows to be either delta-seconds (an
+// integer) or an HTTP-date.
+func retryAfterDelay(resp *http.Response) (time.Duration, bool) {
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if when, err := http.ParseTime(value); err == nil {
+		if delay := time.Until(when); delay > 0 {
+			return delay, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// Retry returns middleware that retries idempotent requests (GET, HEAD, PUT, DELETE, OPTIONS) that fail
+// with a 429 or 5xx status, honoring a Retry-After header when the server sends one and otherwise
+// backing off exponentially per policy, up to policy.MaxRetries attempts.
+func Retry(policy RetryPolicy) func(http.RoundTripper) http.RoundTripper {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if !isIdempotentMethod(req.Method) {
+				return next.RoundTrip(req)
+			}
+
+			var bodyBytes []byte
+			if req.Body != nil {
+				var err error
+				bodyBytes, err = io.ReadAll(req.Body)
+				if err != nil {
+					return nil, err
+				}
+				req.Body.Close()
+			}
+
+			delay := policy.BaseDelay
+			var resp *http.Response
+			var err error
+
+			for attempt := 0; ; attempt++ {
+				if bodyBytes != nil {
+					req.Body = io.NopCloser(bytes.NewReader(bodyBytes))
+				}
+
+				resp, err = next.RoundTrip(req)
+				if err == nil && !isRetryableStatus(resp.StatusCode) {
+					return resp, nil
+				}
+				if attempt >= policy.MaxRetries {
+					return resp, err
+				}
+
+				wait := delay
+				if resp != nil {
+					if retryAfter, ok := retryAfterDelay(resp); ok {
+						wait = retryAfter
+					}
+					resp.Body.Close()
+				}
+
+				select {
+				case <-req.Context().Done():
+					return nil, req.Context().Err()
+				case <-time.After(wait):
+				}
+
+				delay *= 2
+				if delay > policy.MaxDelay {
+					delay = policy.MaxDelay
+				}
+			}
+		})
+	}
+}
+
+// RateLimit returns middleware that smooths outgoing requests to rps requests per second, allowing
+// bursts of up to burst requests, so a caller doesn't blow through the IBM API's per-account quota.
+func RateLimit(rps float64, burst int) func(http.RoundTripper) http.RoundTripper {
+	limiter := rate.NewLimiter(rate.Limit(rps), burst)
+	return func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if err := limiter.Wait(req.Context()); err != nil {
+				return nil, err
+			}
+			return next.RoundTrip(req)
+		})
+	}
+}
@@ -0,0 +1,153 @@
+/**
+ * (C) Copyright IBM Corp. 2021.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestTransactionIDInjectsAndCapturesHeader(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("transaction-id") == "" {
+			t.Errorf("expected transaction-id header to be set on the request")
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: Chain(http.DefaultTransport, TransactionID())}
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	req = req.WithContext(WithTransactionIDCapture(req.Context()))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer resp.Body.Close()
+
+	id, ok := TransactionIDFromContext(req.Context())
+	if !ok || id == "" {
+		t.Fatalf("expected a transaction id to be captured, got %q", id)
+	}
+}
+
+func TestTransactionIDPreservesExistingHeader(t *testing.T) {
+	var seen string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = r.Header.Get("transaction-id")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: Chain(http.DefaultTransport, TransactionID())}
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	req.Header.Set("transaction-id", "caller-supplied-id")
+
+	if _, err := client.Do(req); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if seen != "caller-supplied-id" {
+		t.Fatalf("expected caller-supplied transaction-id to be preserved, got %q", seen)
+	}
+}
+
+func TestRetryRetriesOnServiceUnavailable(t *testing.T) {
+	var attempts int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	policy := RetryPolicy{MaxRetries: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}
+	client := &http.Client{Transport: Chain(http.DefaultTransport, Retry(policy))}
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected eventual 200, got %d", resp.StatusCode)
+	}
+	if attempts != 3 {
+		t.Fatalf("expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestRetryDoesNotRetryNonIdempotentMethods(t *testing.T) {
+	var attempts int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: Chain(http.DefaultTransport, Retry(DefaultRetryPolicy))}
+
+	req, _ := http.NewRequest(http.MethodPost, server.URL, nil)
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer resp.Body.Close()
+
+	if attempts != 1 {
+		t.Fatalf("expected POST not to be retried, got %d attempts", attempts)
+	}
+}
+
+func TestRateLimitSmoothsBursts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// 100 rps with a burst of 1 means the 2nd and 3rd requests each wait ~10ms for a new token, so 3
+	// requests take at least ~20ms; a no-op limiter would finish in well under 1ms.
+	const rps = 100
+	const burst = 1
+	const requests = 3
+	wantFloor := time.Duration(requests-1) * time.Second / rps / 2
+
+	client := &http.Client{Transport: Chain(http.DefaultTransport, RateLimit(rps, burst))}
+
+	start := time.Now()
+	for i := 0; i < requests; i++ {
+		req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		resp.Body.Close()
+	}
+
+	if elapsed := time.Since(start); elapsed < wantFloor {
+		t.Fatalf("expected rate limiting to throttle bursts to at least %s, got %s", wantFloor, elapsed)
+	}
+}
@@ -0,0 +1,939 @@
+/**
+ * (C) Copyright IBM Corp. 2021.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package posturemanagementv1 : Operations and models for the PostureManagementV1 service
+package posturemanagementv1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// DefaultServiceURL is the default URL to make service requests to.
+const DefaultServiceURL = "https://asap.compliance.cloud.ibm.com"
+
+// DefaultServiceName is the default key used to find external configuration information.
+const DefaultServiceName = "posture_management"
+
+// PostureManagementV1 : Run security and compliance checks, collect findings and view reports for your
+// infrastructure that is backed by the posture management service.
+type PostureManagementV1 struct {
+	Service *core.BaseService
+}
+
+// PostureManagementV1Options : Service options for PostureManagementV1.
+type PostureManagementV1Options struct {
+	ServiceName   string
+	URL           string
+	Authenticator core.Authenticator
+
+	// TokenScope, when set, wraps Authenticator in an IamScopedAuthenticator so that every request
+	// carries a token downscoped to the given scope, collector, or profile rather than the full
+	// account. Authenticator must be a *core.IamAuthenticator when TokenScope is set.
+	TokenScope *TokenScope
+
+	// HTTPMiddleware wraps the service's http.Client transport, innermost last, for example to add
+	// transaction-id propagation, retries, or rate limiting. See the posturemanagementv1/middleware
+	// subpackage for ready-made middleware.
+	HTTPMiddleware []func(http.RoundTripper) http.RoundTripper
+}
+
+// NewPostureManagementV1 : constructs an instance of PostureManagementV1 with passed in options.
+func NewPostureManagementV1(options *PostureManagementV1Options) (postureManagement *PostureManagementV1, err error) {
+	authenticator := options.Authenticator
+	if options.TokenScope != nil {
+		iamAuthenticator, ok := authenticator.(*core.IamAuthenticator)
+		if !ok {
+			err = fmt.Errorf("TokenScope requires Authenticator to be a *core.IamAuthenticator")
+			return
+		}
+		authenticator = NewIamScopedAuthenticator(iamAuthenticator, options.TokenScope)
+	}
+
+	serviceOptions := &core.ServiceOptions{
+		URL:           DefaultServiceURL,
+		Authenticator: authenticator,
+	}
+
+	if options.URL != "" {
+		serviceOptions.URL = options.URL
+	}
+
+	baseService, err := core.NewBaseService(serviceOptions)
+	if err != nil {
+		return
+	}
+
+	if err = baseService.SetServiceURL(serviceOptions.URL); err != nil {
+		return
+	}
+
+	if len(options.HTTPMiddleware) > 0 {
+		client := baseService.Client
+		if client == nil {
+			client = http.DefaultClient
+		}
+		wrapped := *client
+		wrapped.Transport = chainRoundTrippers(client.Transport, options.HTTPMiddleware)
+		baseService.Client = &wrapped
+	}
+
+	postureManagement = &PostureManagementV1{
+		Service: baseService,
+	}
+
+	return
+}
+
+// chainRoundTrippers composes middlewares around base in the order given: the first middleware is
+// outermost, so it sees the request first and the response last.
+func chainRoundTrippers(base http.RoundTripper, middlewares []func(http.RoundTripper) http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		base = middlewares[i](base)
+	}
+	return base
+}
+
+// CredentialSupplier supplies the bytes for a posture management credential and its optional PEM key
+// material programmatically, the same way an external-account subject-token supplier hands an OAuth
+// exchange a short-lived token instead of a file on disk. Implementations can fetch secrets from Vault,
+// a KMS, environment variables, or any in-memory secret manager. CreateCredentialWithContext invokes the
+// supplier once, at request-build time; it is not re-invoked on retry, so a supplier backed by a
+// short-lived credential should return one with enough remaining lifetime to outlast the request.
+type CredentialSupplier interface {
+	// CredentialJSON returns the raw credential document to upload.
+	CredentialJSON(ctx context.Context) ([]byte, error)
+	// PEM returns the raw PEM-encoded key material to upload. It may return a nil or empty slice if the
+	// credential does not require PEM material.
+	PEM(ctx context.Context) ([]byte, error)
+}
+
+// CreateCollectorOptions : The CreateCollector options.
+type CreateCollectorOptions struct {
+	// Your account ID.
+	AccountID *string `validate:"required"`
+
+	// A unique name for your collector.
+	CollectorName *string
+
+	// A detailed description of the collector.
+	CollectorDescription *string
+
+	// Whether the collector you are creating is private or public.
+	IsPublic *bool
+
+	// Specify the entity that manages the collector.
+	ManagedBy *string
+
+	// A passphrase for the collector.
+	PassPhrase *string
+
+	// Allows users to set headers on API requests.
+	Headers map[string]string
+}
+
+// NewCreateCollectorOptions : Instantiate CreateCollectorOptions.
+func (postureManagement *PostureManagementV1) NewCreateCollectorOptions(accountID string) *CreateCollectorOptions {
+	return &CreateCollectorOptions{
+		AccountID: core.StringPtr(accountID),
+	}
+}
+
+// SetAccountID : Allow user to set AccountID.
+func (options *CreateCollectorOptions) SetAccountID(accountID string) *CreateCollectorOptions {
+	options.AccountID = core.StringPtr(accountID)
+	return options
+}
+
+// SetCollectorName : Allow user to set CollectorName.
+func (options *CreateCollectorOptions) SetCollectorName(collectorName string) *CreateCollectorOptions {
+	options.CollectorName = core.StringPtr(collectorName)
+	return options
+}
+
+// SetCollectorDescription : Allow user to set CollectorDescription.
+func (options *CreateCollectorOptions) SetCollectorDescription(collectorDescription string) *CreateCollectorOptions {
+	options.CollectorDescription = core.StringPtr(collectorDescription)
+	return options
+}
+
+// SetIsPublic : Allow user to set IsPublic.
+func (options *CreateCollectorOptions) SetIsPublic(isPublic bool) *CreateCollectorOptions {
+	options.IsPublic = core.BoolPtr(isPublic)
+	return options
+}
+
+// SetManagedBy : Allow user to set ManagedBy.
+func (options *CreateCollectorOptions) SetManagedBy(managedBy string) *CreateCollectorOptions {
+	options.ManagedBy = core.StringPtr(managedBy)
+	return options
+}
+
+// SetPassPhrase : Allow user to set PassPhrase.
+func (options *CreateCollectorOptions) SetPassPhrase(passPhrase string) *CreateCollectorOptions {
+	options.PassPhrase = core.StringPtr(passPhrase)
+	return options
+}
+
+// SetHeaders : Allow user to set Headers
+func (options *CreateCollectorOptions) SetHeaders(param map[string]string) *CreateCollectorOptions {
+	options.Headers = param
+	return options
+}
+
+// Collector : The collector that was created.
+type Collector struct {
+	// The unique identifier of the collector.
+	CollectorID *string `json:"collector_id,omitempty"`
+
+	// A unique name for the collector.
+	DisplayName *string `json:"display_name,omitempty"`
+
+	// A detailed description of the collector.
+	Description *string `json:"description,omitempty"`
+
+	// The current status of the collector.
+	Status *string `json:"status,omitempty"`
+}
+
+// CreateCollector : Create a collector
+// Create a collector that will help to discover resources in your environment.
+func (postureManagement *PostureManagementV1) CreateCollector(createCollectorOptions *CreateCollectorOptions) (result *Collector, response *core.DetailedResponse, err error) {
+	return postureManagement.CreateCollectorWithContext(context.Background(), createCollectorOptions)
+}
+
+// CreateCollectorWithContext is an alternate form of the CreateCollector method which supports a Context parameter.
+func (postureManagement *PostureManagementV1) CreateCollectorWithContext(ctx context.Context, createCollectorOptions *CreateCollectorOptions) (result *Collector, response *core.DetailedResponse, err error) {
+	err = core.ValidateNotNil(createCollectorOptions, "createCollectorOptions cannot be nil")
+	if err != nil {
+		return
+	}
+	err = core.ValidateStruct(createCollectorOptions, "createCollectorOptions")
+	if err != nil {
+		return
+	}
+
+	builder := core.NewRequestBuilder(core.POST)
+	builder = builder.WithContext(ctx)
+	builder.EnableGzipCompression = postureManagement.Service.GetEnableGzipCompression()
+	_, err = builder.ResolveRequestURL(postureManagement.Service.Options.URL, `/collectors`, nil)
+	if err != nil {
+		return
+	}
+
+	for headerName, headerValue := range createCollectorOptions.Headers {
+		builder.AddHeader(headerName, headerValue)
+	}
+
+	builder.AddHeader("Accept", "application/json")
+	builder.AddHeader("Account-Id", fmt.Sprint(*createCollectorOptions.AccountID))
+
+	body := make(map[string]interface{})
+	if createCollectorOptions.CollectorName != nil {
+		body["collector_name"] = createCollectorOptions.CollectorName
+	}
+	if createCollectorOptions.CollectorDescription != nil {
+		body["collector_description"] = createCollectorOptions.CollectorDescription
+	}
+	if createCollectorOptions.IsPublic != nil {
+		body["is_public"] = createCollectorOptions.IsPublic
+	}
+	if createCollectorOptions.ManagedBy != nil {
+		body["managed_by"] = createCollectorOptions.ManagedBy
+	}
+	if createCollectorOptions.PassPhrase != nil {
+		body["passphrase"] = createCollectorOptions.PassPhrase
+	}
+	_, err = builder.SetBodyContentJSON(body)
+	if err != nil {
+		return
+	}
+
+	request, err := builder.Build()
+	if err != nil {
+		return
+	}
+
+	var rawResponse map[string]json.RawMessage
+	response, err = postureManagement.Service.Request(request, &rawResponse)
+	if err != nil {
+		return
+	}
+	if rawResponse != nil {
+		err = core.UnmarshalPrimitive(rawResponse, "", &result)
+		if err != nil {
+			return
+		}
+		response.Result = result
+	}
+
+	return
+}
+
+// CreateScopeOptions : The CreateScope options.
+type CreateScopeOptions struct {
+	// Your account ID.
+	AccountID *string `validate:"required"`
+
+	// A unique name for your scope.
+	ScopeName *string
+
+	// A detailed description of the scope.
+	ScopeDescription *string
+
+	// The unique IDs of the collectors that are attached to the scope.
+	CollectorIds []string
+
+	// The unique identifier of the credential.
+	CredentialID *string
+
+	// The environment that the scope is targeting, for example `ibm`, `aws`, `azure`, or `gcp`.
+	EnvironmentType *string
+
+	// CloudAccountID identifies the account within the target cloud environment, for example an AWS
+	// account ID, an Azure subscription ID, or a GCP project ID. Required when EnvironmentType is
+	// anything other than `ibm`.
+	CloudAccountID *string
+
+	// Allows users to set headers on API requests.
+	Headers map[string]string
+}
+
+// NewCreateScopeOptions : Instantiate CreateScopeOptions.
+func (postureManagement *PostureManagementV1) NewCreateScopeOptions(accountID string) *CreateScopeOptions {
+	return &CreateScopeOptions{
+		AccountID: core.StringPtr(accountID),
+	}
+}
+
+// SetScopeName : Allow user to set ScopeName.
+func (options *CreateScopeOptions) SetScopeName(scopeName string) *CreateScopeOptions {
+	options.ScopeName = core.StringPtr(scopeName)
+	return options
+}
+
+// SetScopeDescription : Allow user to set ScopeDescription.
+func (options *CreateScopeOptions) SetScopeDescription(scopeDescription string) *CreateScopeOptions {
+	options.ScopeDescription = core.StringPtr(scopeDescription)
+	return options
+}
+
+// SetCollectorIds : Allow user to set CollectorIds.
+func (options *CreateScopeOptions) SetCollectorIds(collectorIds []string) *CreateScopeOptions {
+	options.CollectorIds = collectorIds
+	return options
+}
+
+// SetCredentialID : Allow user to set CredentialID.
+func (options *CreateScopeOptions) SetCredentialID(credentialID string) *CreateScopeOptions {
+	options.CredentialID = core.StringPtr(credentialID)
+	return options
+}
+
+// SetEnvironmentType : Allow user to set EnvironmentType.
+func (options *CreateScopeOptions) SetEnvironmentType(environmentType string) *CreateScopeOptions {
+	options.EnvironmentType = core.StringPtr(environmentType)
+	return options
+}
+
+// SetEnvironment : Allow user to set EnvironmentType from a typed Environment constant.
+func (options *CreateScopeOptions) SetEnvironment(environment Environment) *CreateScopeOptions {
+	options.EnvironmentType = core.StringPtr(string(environment))
+	return options
+}
+
+// SetCloudAccountID : Allow user to set CloudAccountID.
+func (options *CreateScopeOptions) SetCloudAccountID(cloudAccountID string) *CreateScopeOptions {
+	options.CloudAccountID = core.StringPtr(cloudAccountID)
+	return options
+}
+
+// SetHeaders : Allow user to set Headers
+func (options *CreateScopeOptions) SetHeaders(param map[string]string) *CreateScopeOptions {
+	options.Headers = param
+	return options
+}
+
+// CreateScopeResponse : The scope that was created.
+type CreateScopeResponse struct {
+	// The unique identifier of the scope.
+	ScopeID *string `json:"scope_id,omitempty"`
+
+	// A unique name for the scope.
+	ScopeName *string `json:"scope_name,omitempty"`
+
+	// A detailed description of the scope.
+	ScopeDescription *string `json:"scope_description,omitempty"`
+
+	// The environment that the scope is targeting.
+	Environment *Environment `json:"environment_type,omitempty"`
+}
+
+// CreateScope : Create a scope
+// Create a scope that identifies the assets that you want to target for a collection or scan.
+func (postureManagement *PostureManagementV1) CreateScope(createScopeOptions *CreateScopeOptions) (result *CreateScopeResponse, response *core.DetailedResponse, err error) {
+	return postureManagement.CreateScopeWithContext(context.Background(), createScopeOptions)
+}
+
+// CreateScopeWithContext is an alternate form of the CreateScope method which supports a Context parameter.
+func (postureManagement *PostureManagementV1) CreateScopeWithContext(ctx context.Context, createScopeOptions *CreateScopeOptions) (result *CreateScopeResponse, response *core.DetailedResponse, err error) {
+	err = core.ValidateNotNil(createScopeOptions, "createScopeOptions cannot be nil")
+	if err != nil {
+		return
+	}
+	err = core.ValidateStruct(createScopeOptions, "createScopeOptions")
+	if err != nil {
+		return
+	}
+	if createScopeOptions.EnvironmentType != nil && *createScopeOptions.EnvironmentType != string(EnvironmentIBM) {
+		if createScopeOptions.CloudAccountID == nil || *createScopeOptions.CloudAccountID == "" {
+			err = fmt.Errorf("CloudAccountID is required when EnvironmentType is %q", *createScopeOptions.EnvironmentType)
+			return
+		}
+		if createScopeOptions.CredentialID == nil || *createScopeOptions.CredentialID == "" {
+			err = fmt.Errorf("CredentialID is required when EnvironmentType is %q", *createScopeOptions.EnvironmentType)
+			return
+		}
+	}
+
+	builder := core.NewRequestBuilder(core.POST)
+	builder = builder.WithContext(ctx)
+	builder.EnableGzipCompression = postureManagement.Service.GetEnableGzipCompression()
+	_, err = builder.ResolveRequestURL(postureManagement.Service.Options.URL, `/scopes`, nil)
+	if err != nil {
+		return
+	}
+
+	for headerName, headerValue := range createScopeOptions.Headers {
+		builder.AddHeader(headerName, headerValue)
+	}
+
+	builder.AddHeader("Accept", "application/json")
+	builder.AddHeader("Account-Id", fmt.Sprint(*createScopeOptions.AccountID))
+
+	body := make(map[string]interface{})
+	if createScopeOptions.ScopeName != nil {
+		body["scope_name"] = createScopeOptions.ScopeName
+	}
+	if createScopeOptions.ScopeDescription != nil {
+		body["scope_description"] = createScopeOptions.ScopeDescription
+	}
+	if createScopeOptions.CollectorIds != nil {
+		body["collector_ids"] = createScopeOptions.CollectorIds
+	}
+	if createScopeOptions.CredentialID != nil {
+		body["credential_id"] = createScopeOptions.CredentialID
+	}
+	if createScopeOptions.EnvironmentType != nil {
+		body["environment_type"] = createScopeOptions.EnvironmentType
+	}
+	if createScopeOptions.CloudAccountID != nil {
+		body["cloud_account_id"] = createScopeOptions.CloudAccountID
+	}
+	_, err = builder.SetBodyContentJSON(body)
+	if err != nil {
+		return
+	}
+
+	request, err := builder.Build()
+	if err != nil {
+		return
+	}
+
+	var rawResponse map[string]json.RawMessage
+	response, err = postureManagement.Service.Request(request, &rawResponse)
+	if err != nil {
+		return
+	}
+	if rawResponse != nil {
+		err = core.UnmarshalPrimitive(rawResponse, "", &result)
+		if err != nil {
+			return
+		}
+		response.Result = result
+	}
+
+	return
+}
+
+// ListScopesOptions : The ListScopes options.
+type ListScopesOptions struct {
+	// Your account ID.
+	AccountID *string `validate:"required"`
+
+	// Allows users to set headers on API requests.
+	Headers map[string]string
+}
+
+// NewListScopesOptions : Instantiate ListScopesOptions.
+func (postureManagement *PostureManagementV1) NewListScopesOptions(accountID string) *ListScopesOptions {
+	return &ListScopesOptions{
+		AccountID: core.StringPtr(accountID),
+	}
+}
+
+// SetHeaders : Allow user to set Headers
+func (options *ListScopesOptions) SetHeaders(param map[string]string) *ListScopesOptions {
+	options.Headers = param
+	return options
+}
+
+// Scope : A scope that was previously created.
+type Scope struct {
+	// The unique identifier of the scope.
+	ScopeID *string `json:"scope_id,omitempty"`
+
+	// A unique name for the scope.
+	ScopeName *string `json:"scope_name,omitempty"`
+
+	// A detailed description of the scope.
+	ScopeDescription *string `json:"scope_description,omitempty"`
+
+	// The environment that the scope is targeting.
+	Environment *Environment `json:"environment_type,omitempty"`
+
+	// CloudAccountID identifies the account within the target cloud environment.
+	CloudAccountID *string `json:"cloud_account_id,omitempty"`
+}
+
+// ListScopesResponse : The list of scopes that are available for your account.
+type ListScopesResponse struct {
+	// The scopes that are available for your account.
+	Scopes []Scope `json:"scopes,omitempty"`
+}
+
+// ListScopes : List scopes
+// List all of the scopes that are available in your account.
+func (postureManagement *PostureManagementV1) ListScopes(listScopesOptions *ListScopesOptions) (result *ListScopesResponse, response *core.DetailedResponse, err error) {
+	return postureManagement.ListScopesWithContext(context.Background(), listScopesOptions)
+}
+
+// ListScopesWithContext is an alternate form of the ListScopes method which supports a Context parameter.
+func (postureManagement *PostureManagementV1) ListScopesWithContext(ctx context.Context, listScopesOptions *ListScopesOptions) (result *ListScopesResponse, response *core.DetailedResponse, err error) {
+	err = core.ValidateNotNil(listScopesOptions, "listScopesOptions cannot be nil")
+	if err != nil {
+		return
+	}
+	err = core.ValidateStruct(listScopesOptions, "listScopesOptions")
+	if err != nil {
+		return
+	}
+
+	builder := core.NewRequestBuilder(core.GET)
+	builder = builder.WithContext(ctx)
+	builder.EnableGzipCompression = postureManagement.Service.GetEnableGzipCompression()
+	_, err = builder.ResolveRequestURL(postureManagement.Service.Options.URL, `/scopes`, nil)
+	if err != nil {
+		return
+	}
+
+	for headerName, headerValue := range listScopesOptions.Headers {
+		builder.AddHeader(headerName, headerValue)
+	}
+
+	builder.AddHeader("Accept", "application/json")
+	builder.AddHeader("Account-Id", fmt.Sprint(*listScopesOptions.AccountID))
+
+	request, err := builder.Build()
+	if err != nil {
+		return
+	}
+
+	var rawResponse map[string]json.RawMessage
+	response, err = postureManagement.Service.Request(request, &rawResponse)
+	if err != nil {
+		return
+	}
+	if rawResponse != nil {
+		err = core.UnmarshalPrimitive(rawResponse, "", &result)
+		if err != nil {
+			return
+		}
+		response.Result = result
+	}
+
+	return
+}
+
+// CreateCredentialOptions : The CreateCredential options.
+type CreateCredentialOptions struct {
+	// Your account ID.
+	AccountID *string `validate:"required"`
+
+	// The credential document to upload, for example a service-to-service authorization JSON file.
+	CredentialFile io.ReadCloser
+
+	// The PEM-encoded key material that accompanies the credential document, if any.
+	PemFile io.ReadCloser
+
+	// A CredentialSupplier that fetches the credential document and PEM key material at request time,
+	// instead of reading them from disk. When set, it takes precedence over CredentialFile and PemFile.
+	CredentialSupplier CredentialSupplier
+
+	// Allows users to set headers on API requests.
+	Headers map[string]string
+}
+
+// NewCreateCredentialOptions : Instantiate CreateCredentialOptions.
+func (postureManagement *PostureManagementV1) NewCreateCredentialOptions(accountID string, credentialFile io.ReadCloser) *CreateCredentialOptions {
+	return &CreateCredentialOptions{
+		AccountID:      core.StringPtr(accountID),
+		CredentialFile: credentialFile,
+	}
+}
+
+// SetCredentialFile : Allow user to set CredentialFile.
+func (options *CreateCredentialOptions) SetCredentialFile(credentialFile io.ReadCloser) *CreateCredentialOptions {
+	options.CredentialFile = credentialFile
+	return options
+}
+
+// SetPemFile : Allow user to set PemFile.
+func (options *CreateCredentialOptions) SetPemFile(pemFile io.ReadCloser) *CreateCredentialOptions {
+	options.PemFile = pemFile
+	return options
+}
+
+// SetCredentialSupplier : Allow user to set CredentialSupplier. Setting a supplier lets the credential and PEM
+// bytes be fetched from Vault, a KMS, an env var, or any other in-memory secret source at request time,
+// instead of requiring the caller to first write them to disk.
+func (options *CreateCredentialOptions) SetCredentialSupplier(credentialSupplier CredentialSupplier) *CreateCredentialOptions {
+	options.CredentialSupplier = credentialSupplier
+	return options
+}
+
+// SetHeaders : Allow user to set Headers
+func (options *CreateCredentialOptions) SetHeaders(param map[string]string) *CreateCredentialOptions {
+	options.Headers = param
+	return options
+}
+
+// CredentialResponse : The credential that was created.
+type CredentialResponse struct {
+	// The unique identifier of the credential.
+	CredentialID *string `json:"credential_id,omitempty"`
+
+	// The type of credential.
+	Type *string `json:"type,omitempty"`
+}
+
+// CreateCredential : Create a credential
+// Create a credential that can be used to authenticate with the resources that are located in your
+// environment. The credential and any accompanying key material are streamed directly to the service and
+// are never written to disk by the SDK.
+func (postureManagement *PostureManagementV1) CreateCredential(createCredentialOptions *CreateCredentialOptions) (result *CredentialResponse, response *core.DetailedResponse, err error) {
+	return postureManagement.CreateCredentialWithContext(context.Background(), createCredentialOptions)
+}
+
+// CreateCredentialWithContext is an alternate form of the CreateCredential method which supports a Context parameter.
+func (postureManagement *PostureManagementV1) CreateCredentialWithContext(ctx context.Context, createCredentialOptions *CreateCredentialOptions) (result *CredentialResponse, response *core.DetailedResponse, err error) {
+	err = core.ValidateNotNil(createCredentialOptions, "createCredentialOptions cannot be nil")
+	if err != nil {
+		return
+	}
+	if createCredentialOptions.CredentialSupplier == nil && createCredentialOptions.CredentialFile == nil {
+		err = fmt.Errorf("one of CredentialFile or CredentialSupplier must be set on createCredentialOptions")
+		return
+	}
+	err = core.ValidateStruct(createCredentialOptions, "createCredentialOptions")
+	if err != nil {
+		return
+	}
+
+	builder := core.NewRequestBuilder(core.POST)
+	builder = builder.WithContext(ctx)
+	builder.EnableGzipCompression = postureManagement.Service.GetEnableGzipCompression()
+	_, err = builder.ResolveRequestURL(postureManagement.Service.Options.URL, `/credentials`, nil)
+	if err != nil {
+		return
+	}
+
+	for headerName, headerValue := range createCredentialOptions.Headers {
+		builder.AddHeader(headerName, headerValue)
+	}
+
+	builder.AddHeader("Accept", "application/json")
+	builder.AddHeader("Account-Id", fmt.Sprint(*createCredentialOptions.AccountID))
+
+	var credentialBytes io.Reader = createCredentialOptions.CredentialFile
+	var pemBytes io.Reader = createCredentialOptions.PemFile
+
+	if createCredentialOptions.CredentialSupplier != nil {
+		credentialJSON, supplierErr := createCredentialOptions.CredentialSupplier.CredentialJSON(ctx)
+		if supplierErr != nil {
+			err = fmt.Errorf("failed to fetch credential from supplier: %s", supplierErr)
+			return
+		}
+		credentialBytes = bytes.NewReader(credentialJSON)
+
+		pem, supplierErr := createCredentialOptions.CredentialSupplier.PEM(ctx)
+		if supplierErr != nil {
+			err = fmt.Errorf("failed to fetch PEM from supplier: %s", supplierErr)
+			return
+		}
+		if len(pem) > 0 {
+			pemBytes = bytes.NewReader(pem)
+		} else {
+			pemBytes = nil
+		}
+	}
+
+	builder.AddFormData("file", "filename.json", "application/octet-stream", credentialBytes)
+	if pemBytes != nil {
+		builder.AddFormData("pem_file", "filename.pem", "application/octet-stream", pemBytes)
+	}
+
+	request, err := builder.Build()
+	if err != nil {
+		return
+	}
+
+	var rawResponse map[string]json.RawMessage
+	response, err = postureManagement.Service.Request(request, &rawResponse)
+	if err != nil {
+		return
+	}
+	if rawResponse != nil {
+		err = core.UnmarshalPrimitive(rawResponse, "", &result)
+		if err != nil {
+			return
+		}
+		response.Result = result
+	}
+
+	return
+}
+
+// ScanSummariesOptions : The ScanSummaries options.
+type ScanSummariesOptions struct {
+	// The unique identifier of the scope.
+	ScopeID *string `validate:"required"`
+
+	// Your account ID.
+	AccountID *string `validate:"required"`
+
+	// The unique identifier of the profile.
+	ProfileID *string
+
+	// The unique identifier of the group profile.
+	GroupProfileID *string
+
+	// Allows users to set headers on API requests.
+	Headers map[string]string
+}
+
+// NewScanSummariesOptions : Instantiate ScanSummariesOptions.
+func (postureManagement *PostureManagementV1) NewScanSummariesOptions(scopeID string, accountID string) *ScanSummariesOptions {
+	return &ScanSummariesOptions{
+		ScopeID:   core.StringPtr(scopeID),
+		AccountID: core.StringPtr(accountID),
+	}
+}
+
+// SetProfileID : Allow user to set ProfileID.
+func (options *ScanSummariesOptions) SetProfileID(profileID string) *ScanSummariesOptions {
+	options.ProfileID = core.StringPtr(profileID)
+	return options
+}
+
+// SetGroupProfileID : Allow user to set GroupProfileID.
+func (options *ScanSummariesOptions) SetGroupProfileID(groupProfileID string) *ScanSummariesOptions {
+	options.GroupProfileID = core.StringPtr(groupProfileID)
+	return options
+}
+
+// SetHeaders : Allow user to set Headers
+func (options *ScanSummariesOptions) SetHeaders(param map[string]string) *ScanSummariesOptions {
+	options.Headers = param
+	return options
+}
+
+// ScanSummary : The summary of a scan.
+type ScanSummary struct {
+	// The unique identifier of the scan.
+	ScanID *string `json:"scan_id,omitempty"`
+
+	// The current status of the scan.
+	Status *string `json:"status,omitempty"`
+
+	// The environment of the scope that was scanned.
+	Environment *Environment `json:"environment_type,omitempty"`
+}
+
+// ScanSummaries : Initiate a scan
+// Initiate a scan of a scope against the given profile, and return the current summary of that scan.
+func (postureManagement *PostureManagementV1) ScanSummaries(scanSummariesOptions *ScanSummariesOptions) (result *ScanSummary, response *core.DetailedResponse, err error) {
+	return postureManagement.ScanSummariesWithContext(context.Background(), scanSummariesOptions)
+}
+
+// ScanSummariesWithContext is an alternate form of the ScanSummaries method which supports a Context parameter.
+func (postureManagement *PostureManagementV1) ScanSummariesWithContext(ctx context.Context, scanSummariesOptions *ScanSummariesOptions) (result *ScanSummary, response *core.DetailedResponse, err error) {
+	err = core.ValidateNotNil(scanSummariesOptions, "scanSummariesOptions cannot be nil")
+	if err != nil {
+		return
+	}
+	err = core.ValidateStruct(scanSummariesOptions, "scanSummariesOptions")
+	if err != nil {
+		return
+	}
+
+	builder := core.NewRequestBuilder(core.POST)
+	builder = builder.WithContext(ctx)
+	builder.EnableGzipCompression = postureManagement.Service.GetEnableGzipCompression()
+	_, err = builder.ResolveRequestURL(postureManagement.Service.Options.URL, `/scans/summaries`, nil)
+	if err != nil {
+		return
+	}
+
+	for headerName, headerValue := range scanSummariesOptions.Headers {
+		builder.AddHeader(headerName, headerValue)
+	}
+
+	builder.AddHeader("Accept", "application/json")
+	builder.AddHeader("Account-Id", fmt.Sprint(*scanSummariesOptions.AccountID))
+
+	body := make(map[string]interface{})
+	body["scope_id"] = scanSummariesOptions.ScopeID
+	if scanSummariesOptions.ProfileID != nil {
+		body["profile_id"] = scanSummariesOptions.ProfileID
+	}
+	if scanSummariesOptions.GroupProfileID != nil {
+		body["group_profile_id"] = scanSummariesOptions.GroupProfileID
+	}
+	_, err = builder.SetBodyContentJSON(body)
+	if err != nil {
+		return
+	}
+
+	request, err := builder.Build()
+	if err != nil {
+		return
+	}
+
+	var rawResponse map[string]json.RawMessage
+	response, err = postureManagement.Service.Request(request, &rawResponse)
+	if err != nil {
+		return
+	}
+	if rawResponse != nil {
+		err = core.UnmarshalPrimitive(rawResponse, "", &result)
+		if err != nil {
+			return
+		}
+		response.Result = result
+	}
+
+	return
+}
+
+// ListProfilesOptions : The ListProfiles options.
+type ListProfilesOptions struct {
+	// Your account ID.
+	AccountID *string `validate:"required"`
+
+	// Allows users to set headers on API requests.
+	Headers map[string]string
+}
+
+// NewListProfilesOptions : Instantiate ListProfilesOptions.
+func (postureManagement *PostureManagementV1) NewListProfilesOptions(accountID string) *ListProfilesOptions {
+	return &ListProfilesOptions{
+		AccountID: core.StringPtr(accountID),
+	}
+}
+
+// SetHeaders : Allow user to set Headers
+func (options *ListProfilesOptions) SetHeaders(param map[string]string) *ListProfilesOptions {
+	options.Headers = param
+	return options
+}
+
+// Profile : A profile that is available for scanning.
+type Profile struct {
+	// The unique identifier of the profile.
+	ProfileID *string `json:"profile_id,omitempty"`
+
+	// The name of the profile.
+	Name *string `json:"name,omitempty"`
+}
+
+// ListProfilesResponse : The list of profiles that are available for your account.
+type ListProfilesResponse struct {
+	// The profiles that are available for your account.
+	Profiles []Profile `json:"profiles,omitempty"`
+}
+
+// ListProfiles : List profiles
+// List all of the profiles that are available to scan against in your account.
+func (postureManagement *PostureManagementV1) ListProfiles(listProfilesOptions *ListProfilesOptions) (result *ListProfilesResponse, response *core.DetailedResponse, err error) {
+	return postureManagement.ListProfilesWithContext(context.Background(), listProfilesOptions)
+}
+
+// ListProfilesWithContext is an alternate form of the ListProfiles method which supports a Context parameter.
+func (postureManagement *PostureManagementV1) ListProfilesWithContext(ctx context.Context, listProfilesOptions *ListProfilesOptions) (result *ListProfilesResponse, response *core.DetailedResponse, err error) {
+	err = core.ValidateNotNil(listProfilesOptions, "listProfilesOptions cannot be nil")
+	if err != nil {
+		return
+	}
+	err = core.ValidateStruct(listProfilesOptions, "listProfilesOptions")
+	if err != nil {
+		return
+	}
+
+	builder := core.NewRequestBuilder(core.GET)
+	builder = builder.WithContext(ctx)
+	builder.EnableGzipCompression = postureManagement.Service.GetEnableGzipCompression()
+	_, err = builder.ResolveRequestURL(postureManagement.Service.Options.URL, `/profiles`, nil)
+	if err != nil {
+		return
+	}
+
+	for headerName, headerValue := range listProfilesOptions.Headers {
+		builder.AddHeader(headerName, headerValue)
+	}
+
+	builder.AddHeader("Accept", "application/json")
+	builder.AddHeader("Account-Id", fmt.Sprint(*listProfilesOptions.AccountID))
+
+	request, err := builder.Build()
+	if err != nil {
+		return
+	}
+
+	var rawResponse map[string]json.RawMessage
+	response, err = postureManagement.Service.Request(request, &rawResponse)
+	if err != nil {
+		return
+	}
+	if rawResponse != nil {
+		err = core.UnmarshalPrimitive(rawResponse, "", &result)
+		if err != nil {
+			return
+		}
+		response.Result = result
+	}
+
+	return
+}
@@ -19,6 +19,7 @@ package posturemanagementv1_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -26,10 +27,12 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/IBM/go-sdk-core/v5/core"
 	"github.com/google/uuid"
 	scc "github.com/ibm-cloud-security/scc-go-sdk/posturemanagementv1"
+	"github.com/ibm-cloud-security/scc-go-sdk/posturemanagementv1/middleware"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -44,6 +47,13 @@ import (
 
 var accessToken *string
 
+// rawRequestClient is shared by the helpers below that issue requests directly instead of going
+// through PostureManagementV1, so they get transaction-id propagation from the same TransactionID
+// middleware that demoCreateCollector passes to the service through HTTPMiddleware.
+var rawRequestClient = &http.Client{
+	Transport: middleware.Chain(http.DefaultTransport, middleware.TransactionID()),
+}
+
 var _ = Describe(`SCC test`, func() {
 
 	var (
@@ -119,12 +129,56 @@ var _ = Describe(`SCC test`, func() {
 				Expect(response.StatusCode).To(Equal(200))
 				Expect(reply).ToNot(BeNil())
 			})
+			It(`Viewer-scoped token cannot hard-delete the scope`, func() {
+				role := "viewer"
+				viewerAuthenticator := scc.NewIamScopedAuthenticator(authenticator, &scc.TokenScope{
+					ScopeID: scopeId,
+					Role:    &role,
+				})
+
+				responseCode := hardDeleteScopeWithAuthenticator(viewerAuthenticator, scopeId)
+				Expect(responseCode).ToNot(Equal(200))
+			})
 			It(`Delete scope for cleanup`, func() {
 				response := hardDeleteScope(scopeId)
 				Expect(response).To(Equal(200))
 
 			})
 		})
+		Describe(`Multi-cloud scope suite`, func() {
+			It(`Lists the supported environments`, func() {
+				service, _ := scc.NewPostureManagementV1(&scc.PostureManagementV1Options{
+					Authenticator: authenticator,
+					URL:           apiUrl,
+				})
+
+				Expect(service.SupportedEnvironments()).To(ContainElement(scc.EnvironmentAWS))
+				Expect(service.SupportedEnvironments()).To(ContainElement(scc.EnvironmentAzure))
+				Expect(service.SupportedEnvironments()).To(ContainElement(scc.EnvironmentGCP))
+			})
+			It(`Create AWS scope`, func() {
+				service, _ := scc.NewPostureManagementV1(&scc.PostureManagementV1Options{
+					Authenticator: authenticator,
+					URL:           apiUrl,
+				})
+
+				source := service.NewAwsScopeOptions(accountId, "5645", os.Getenv("AWS_ACCOUNT_ID"))
+				source.SetScopeName("aws-scope-" + uuidWithHyphen)
+				source.SetCollectorIds([]string{"1417"})
+
+				reply, response, err := service.CreateScope(source)
+
+				if err != nil {
+					fmt.Println(response.Result)
+					fmt.Println("Failed to create AWS scope: ", err)
+					return
+				}
+				Expect(response.StatusCode).To(Equal(200))
+				Expect(reply).ToNot(BeNil())
+
+				hardDeleteScope(reply.ScopeID)
+			})
+		})
 		Describe(`Create credential suite`, func() {
 			It(`Create credential`, func() {
 				credentialPath := os.Getenv("CREDENTIAL_PATH")
@@ -173,6 +227,48 @@ var _ = Describe(`SCC test`, func() {
 				Expect(response.StatusCode).To(Equal(200))
 				Expect(reply).ToNot(BeNil())
 			})
+			It(`Start scan and wait for completion`, func() {
+				service, _ := scc.NewPostureManagementV1(&scc.PostureManagementV1Options{
+					Authenticator: authenticator,
+					URL:           "https://asap-dev.compliance.test.cloud.ibm.com", //Specify url or use default
+				})
+
+				ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+				defer cancel()
+
+				source := service.NewStartScanOptions("1188", accountId)
+				source.SetProfileID("48")
+
+				handle, err := service.StartScan(ctx, source)
+				if err != nil {
+					fmt.Println("Failed to start scan: ", err)
+					return
+				}
+
+				findings, err := handle.Findings()
+				Expect(err).To(BeNil())
+
+				drained := make(chan struct{})
+				go func() {
+					defer close(drained)
+					for range findings {
+						// findings channel closes once the background poll loop observes a terminal scan status
+					}
+				}()
+
+				summary, err := handle.Wait(ctx)
+				if err != nil {
+					fmt.Println("Failed waiting for scan: ", err)
+					return
+				}
+				Expect(summary).ToNot(BeNil())
+
+				<-drained
+
+				persisted, err := handle.MarshalBinary()
+				Expect(err).To(BeNil())
+				Expect(persisted).ToNot(BeNil())
+			})
 		})
 
 	})
@@ -186,6 +282,10 @@ var _ = Describe(`SCC test`, func() {
 			credentialId = demoCreateCredential()
 			Expect(credentialId).ToNot(BeNil())
 		})
+		It(`Create Credential with supplier`, func() {
+			supplierCredentialId := demoCreateCredentialWithSupplier()
+			Expect(supplierCredentialId).ToNot(BeNil())
+		})
 		It(`Create Scope`, func() {
 			collectorIds = append(collectorIds, *collectorId)
 			scopeId = demoCreateScope(credentialId, collectorIds)
@@ -254,15 +354,13 @@ func hardDeleteCollector(collectorId *string) int {
 	url := "https://asap-dev.compliance.test.cloud.ibm.com/alpha/v1.0/collectors/" + collectorIdValue
 	method := "DELETE"
 
-	client := &http.Client{}
 	req, _ := http.NewRequest(method, url, nil)
 
 	req.Header.Add("Content-Type", "multipart/form-data")
 	req.Header.Add("Authorization", *authToken)
 	req.Header.Add("REALM", accountId)
-	req.Header.Add("transaction-id", uuid.New().String())
 
-	res, _ := client.Do(req)
+	res, _ := rawRequestClient.Do(req)
 	defer res.Body.Close()
 
 	ioutil.ReadAll(res.Body)
@@ -278,15 +376,40 @@ func hardDeleteScope(scopeId *string) int {
 	url := "https://asap-dev.compliance.test.cloud.ibm.com/alpha/v1.0/schemas/" + scopeIdValue
 	method := "DELETE"
 
-	client := &http.Client{}
 	req, _ := http.NewRequest(method, url, nil)
 
 	req.Header.Add("Content-Type", "multipart/form-data")
 	req.Header.Add("Authorization", *authToken)
 	req.Header.Add("REALM", accountId)
-	req.Header.Add("transaction-id", uuid.New().String())
 
-	res, _ := client.Do(req)
+	res, _ := rawRequestClient.Do(req)
+	defer res.Body.Close()
+
+	ioutil.ReadAll(res.Body)
+
+	return res.StatusCode
+}
+
+// hardDeleteScopeWithAuthenticator mirrors hardDeleteScope but authenticates with the given
+// core.Authenticator instead of a raw full-privilege token, so it can be exercised with a viewer-scoped
+// token that is expected to be denied.
+func hardDeleteScopeWithAuthenticator(authenticator core.Authenticator, scopeId *string) int {
+	accountId := os.Getenv("ACCOUNT_ID")
+	scopeIdValue := *scopeId
+	url := "https://asap-dev.compliance.test.cloud.ibm.com/alpha/v1.0/schemas/" + scopeIdValue
+	method := "DELETE"
+
+	req, _ := http.NewRequest(method, url, nil)
+
+	req.Header.Add("Content-Type", "multipart/form-data")
+	req.Header.Add("REALM", accountId)
+
+	if err := authenticator.Authenticate(req); err != nil {
+		fmt.Println("Failed to authenticate hard delete request: ", err)
+		return 0
+	}
+
+	res, _ := rawRequestClient.Do(req)
 	defer res.Body.Close()
 
 	ioutil.ReadAll(res.Body)
@@ -321,15 +444,13 @@ func demoDiscovery(gatewayIds []string, scopeId string) {
 	requestByte, _ := json.Marshal(tld)
 	requestReader := bytes.NewReader(requestByte)
 
-	client := &http.Client{}
 	req, _ := http.NewRequest(method, url, requestReader)
 
 	req.Header.Add("Content-Type", "application/json")
 	req.Header.Add("Authorization", authToken)
 	req.Header.Add("REALM", accountId)
-	req.Header.Add("transaction-id", uuid.New().String())
 
-	res, _ := client.Do(req)
+	res, _ := rawRequestClient.Do(req)
 	defer res.Body.Close()
 
 	ioutil.ReadAll(res.Body)
@@ -354,6 +475,11 @@ func demoCreateCollector() *string {
 	service, _ := scc.NewPostureManagementV1(&scc.PostureManagementV1Options{
 		Authenticator: authenticator,
 		URL:           apiUrl, //Specify url or use default
+		HTTPMiddleware: []func(http.RoundTripper) http.RoundTripper{
+			middleware.TransactionID(),
+			middleware.Retry(middleware.DefaultRetryPolicy),
+			middleware.RateLimit(10, 5),
+		},
 	})
 
 	source := service.NewCreateCollectorOptions(accountId)
@@ -412,6 +538,58 @@ func demoCreateCredential() string {
 
 	return *reply.CredentialID
 }
+// envCredentialSupplier implements scc.CredentialSupplier by reading the credential document and PEM
+// key material from environment variables instead of the filesystem.
+type envCredentialSupplier struct {
+	credentialJSON []byte
+	pem            []byte
+}
+
+func (s *envCredentialSupplier) CredentialJSON(ctx context.Context) ([]byte, error) {
+	return s.credentialJSON, nil
+}
+
+func (s *envCredentialSupplier) PEM(ctx context.Context) ([]byte, error) {
+	return s.pem, nil
+}
+
+func demoCreateCredentialWithSupplier() string {
+	apiKey := os.Getenv("IAM_API_KEY")
+	authUrl := os.Getenv("IAM_APIKEY_URL")
+	accountId := os.Getenv("ACCOUNT_ID")
+	apiUrl := os.Getenv("API_URL")
+
+	authenticator := &core.IamAuthenticator{
+		ApiKey: apiKey,
+		URL:    authUrl, //use for dev/preprod env
+	}
+
+	service, _ := scc.NewPostureManagementV1(&scc.PostureManagementV1Options{
+		Authenticator: authenticator,
+		URL:           apiUrl, //Specify url or use default
+	})
+
+	supplier := &envCredentialSupplier{
+		credentialJSON: []byte(os.Getenv("CREDENTIAL_JSON")),
+		pem:            []byte(os.Getenv("CREDENTIAL_PEM")),
+	}
+
+	source := service.NewCreateCredentialOptions(accountId, nil)
+	source.SetCredentialSupplier(supplier)
+
+	reply, response, err := service.CreateCredential(source)
+
+	if err != nil {
+		fmt.Println(response.Result)
+		fmt.Println("Failed to create credential with supplier: ", err)
+		return ""
+	}
+
+	Expect(response.StatusCode).To(Equal(201))
+
+	return *reply.CredentialID
+}
+
 func demoCreateScope(credentialId string, collectorIds []string) *string {
 	uuidWithHyphen := uuid.New().String()
 	apiKey := os.Getenv("IAM_API_KEY")
@@ -0,0 +1,735 @@
+/**
+ * (C) Copyright IBM Corp. 2021.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package posturemanagementv1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// errScanHandleClosed is returned by the background polling loop when it stops because Close was called
+// rather than because ctx was done or the scan reached a terminal state.
+var errScanHandleClosed = errors.New("posturemanagementv1: scan handle closed")
+
+// Scan status values that terminate a ScanHandle's polling loop.
+const (
+	ScanStatusCompleted = "completed"
+	ScanStatusFailed    = "failed"
+	ScanStatusCancelled = "cancelled"
+)
+
+func isTerminalScanStatus(status string) bool {
+	switch status {
+	case ScanStatusCompleted, ScanStatusFailed, ScanStatusCancelled:
+		return true
+	default:
+		return false
+	}
+}
+
+// Finding is a single result produced by a scan.
+type Finding struct {
+	// The unique identifier of the finding.
+	FindingID *string `json:"finding_id,omitempty"`
+
+	// The unique identifier of the rule that produced the finding.
+	RuleID *string `json:"rule_id,omitempty"`
+
+	// The severity of the finding, for example `low`, `medium`, or `high`.
+	Severity *string `json:"severity,omitempty"`
+
+	// The current status of the finding, for example `pass` or `fail`.
+	Status *string `json:"status,omitempty"`
+}
+
+// StartScanOptions : The StartScan options.
+type StartScanOptions struct {
+	// The unique identifier of the scope to scan.
+	ScopeID *string `validate:"required"`
+
+	// Your account ID.
+	AccountID *string `validate:"required"`
+
+	// The unique identifier of the profile.
+	ProfileID *string
+
+	// The unique identifier of the group profile.
+	GroupProfileID *string
+}
+
+// NewStartScanOptions : Instantiate StartScanOptions.
+func (postureManagement *PostureManagementV1) NewStartScanOptions(scopeID string, accountID string) *StartScanOptions {
+	return &StartScanOptions{
+		ScopeID:   core.StringPtr(scopeID),
+		AccountID: core.StringPtr(accountID),
+	}
+}
+
+// SetProfileID : Allow user to set ProfileID.
+func (options *StartScanOptions) SetProfileID(profileID string) *StartScanOptions {
+	options.ProfileID = core.StringPtr(profileID)
+	return options
+}
+
+// SetGroupProfileID : Allow user to set GroupProfileID.
+func (options *StartScanOptions) SetGroupProfileID(groupProfileID string) *StartScanOptions {
+	options.GroupProfileID = core.StringPtr(groupProfileID)
+	return options
+}
+
+// ScanHandle tracks a scan that was started with StartScan. It lets callers wait for the scan to reach
+// a terminal state, poll for its current status, cancel it mid-flight, or stream findings as they are
+// discovered, instead of hand-rolling a retry loop around ScanSummaries.
+//
+// StartScan runs a single poll-until-terminal loop in its own goroutine, under the context passed to
+// StartScan: Wait only ever waits on a completion signal, not on the findings channel. The loop does
+// block while the findings channel's 64-entry buffer is full, so a Findings consumer that falls more
+// than 64 findings behind delays Wait until it catches up, ctx is done, or Close is called. That
+// background loop is also the only goroutine that
+// drains findings, advances the findings offset, and closes the findings channel, so Poll is safe to
+// call concurrently with it: Poll only reports the current summary and never touches the channel or
+// offset itself. Call Close to stop the background loop early, for example when abandoning a handle
+// before the scan reaches a terminal state.
+type ScanHandle struct {
+	service   *PostureManagementV1
+	accountID string
+	scanID    string
+
+	mutex         sync.Mutex
+	findingOffset int64
+	findingsCh    chan Finding
+	closeFindings sync.Once
+
+	ctx       context.Context
+	stop      chan struct{}
+	stopOnce  sync.Once
+	done      chan struct{}
+	result    *ScanSummary
+	resultErr error
+}
+
+// StartScan kicks off a scan of a scope against a profile, returns a ScanHandle for tracking it, and
+// begins polling for completion in the background immediately. The background loop runs under ctx and
+// stops if ctx is done, if the scan reaches a terminal state, or if the handle's Close method is called.
+func (postureManagement *PostureManagementV1) StartScan(ctx context.Context, options *StartScanOptions) (*ScanHandle, error) {
+	err := core.ValidateNotNil(options, "startScanOptions cannot be nil")
+	if err != nil {
+		return nil, err
+	}
+
+	scanSummariesOptions := postureManagement.NewScanSummariesOptions(*options.ScopeID, *options.AccountID)
+	if options.ProfileID != nil {
+		scanSummariesOptions.SetProfileID(*options.ProfileID)
+	}
+	if options.GroupProfileID != nil {
+		scanSummariesOptions.SetGroupProfileID(*options.GroupProfileID)
+	}
+
+	summary, _, err := postureManagement.ScanSummariesWithContext(ctx, scanSummariesOptions)
+	if err != nil {
+		return nil, err
+	}
+	if summary == nil || summary.ScanID == nil {
+		return nil, fmt.Errorf("ScanSummaries did not return a scan_id")
+	}
+
+	handle := &ScanHandle{
+		service:    postureManagement,
+		accountID:  *options.AccountID,
+		scanID:     *summary.ScanID,
+		findingsCh: make(chan Finding, 64),
+		ctx:        ctx,
+		stop:       make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+
+	go handle.pollUntilTerminal()
+
+	return handle, nil
+}
+
+// ScanID returns the unique identifier of the tracked scan.
+func (handle *ScanHandle) ScanID() string {
+	return handle.scanID
+}
+
+// Poll fetches the scan's current summary. Unlike the background loop started by StartScan, Poll does
+// not drain findings or touch the channel returned by Findings, so it is safe to call at any time,
+// including concurrently with that background loop, for manual status checks that don't need findings.
+func (handle *ScanHandle) Poll(ctx context.Context) (*ScanSummary, error) {
+	return handle.fetchSummary(ctx)
+}
+
+func (handle *ScanHandle) fetchSummary(ctx context.Context) (*ScanSummary, error) {
+	options := handle.service.NewGetScanSummaryOptions(handle.accountID, handle.scanID)
+	summary, response, err := handle.service.GetScanSummaryWithContext(ctx, options)
+	if err != nil {
+		return nil, retryAwareError(response, err)
+	}
+	return summary, nil
+}
+
+// pollUntilTerminal is started by StartScan and runs for the lifetime of the scan, polling with
+// exponential backoff (2s, capped at 30s, with +/-20% jitter) and honoring any Retry-After the backend
+// sends on a 429 or 503, flooring it to the initial backoff so a zero or already-past Retry-After value
+// can't turn into a busy loop. It is the sole reader of the scan summary used for findings/terminal-state
+// decisions, the sole writer to findingsCh and findingOffset, and the sole closer of findingsCh, so Poll
+// can run concurrently without racing it. It stops if handle.ctx is done or Close is called.
+func (handle *ScanHandle) pollUntilTerminal() {
+	const (
+		initialBackoff = 2 * time.Second
+		maxBackoff     = 30 * time.Second
+	)
+	backoff := initialBackoff
+
+	defer handle.closeFindingsChannel()
+	defer close(handle.done)
+
+	for {
+		summary, err := handle.fetchSummary(handle.ctx)
+		if err == nil {
+			err = handle.drainFindings(handle.ctx)
+		}
+		if err != nil {
+			if retryAfter, ok := err.(*retryAfterError); ok {
+				wait := retryAfter.delay
+				if wait < initialBackoff {
+					wait = initialBackoff
+				}
+				if !handle.sleep(wait) {
+					handle.setResult(nil, handle.stopErr())
+					return
+				}
+				continue
+			}
+			handle.setResult(nil, err)
+			return
+		}
+
+		if summary != nil && summary.Status != nil && isTerminalScanStatus(*summary.Status) {
+			handle.setResult(summary, nil)
+			return
+		}
+
+		if !handle.sleep(withJitter(backoff)) {
+			handle.setResult(nil, handle.stopErr())
+			return
+		}
+
+		backoff *= 2
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+// sleep waits for d and returns true, or returns false early if handle.ctx is done or Close is called.
+func (handle *ScanHandle) sleep(d time.Duration) bool {
+	select {
+	case <-time.After(d):
+		return true
+	case <-handle.ctx.Done():
+		return false
+	case <-handle.stop:
+		return false
+	}
+}
+
+func (handle *ScanHandle) stopErr() error {
+	if err := handle.ctx.Err(); err != nil {
+		return err
+	}
+	return errScanHandleClosed
+}
+
+func (handle *ScanHandle) setResult(summary *ScanSummary, err error) {
+	handle.mutex.Lock()
+	defer handle.mutex.Unlock()
+	handle.result = summary
+	handle.resultErr = err
+}
+
+// Wait blocks until the background polling loop started by StartScan observes a terminal scan state
+// (completed, failed, or cancelled), or until ctx is done, without itself touching the findings channel.
+func (handle *ScanHandle) Wait(ctx context.Context) (*ScanSummary, error) {
+	select {
+	case <-handle.done:
+		handle.mutex.Lock()
+		defer handle.mutex.Unlock()
+		return handle.result, handle.resultErr
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Close stops the background polling loop started by StartScan (or resumed by RehydrateScanHandle), if
+// it is still running, causing Wait to return errScanHandleClosed and the findings channel to close. It
+// is safe to call more than once, and a no-op on a handle whose loop was never started (for example one
+// restored via UnmarshalBinary alone rather than RehydrateScanHandle).
+func (handle *ScanHandle) Close() {
+	if handle.stop == nil {
+		return
+	}
+	handle.stopOnce.Do(func() {
+		close(handle.stop)
+	})
+}
+
+// Cancel requests that the scan stop running.
+func (handle *ScanHandle) Cancel(ctx context.Context) (*ScanSummary, error) {
+	options := handle.service.NewCancelScanOptions(handle.accountID, handle.scanID)
+	result, _, err := handle.service.CancelScanWithContext(ctx, options)
+	return result, err
+}
+
+// Findings returns the channel that newly discovered findings are pushed onto as the background
+// polling loop started by StartScan observes them. The channel is closed once that loop reaches a
+// terminal scan state, so callers should range over it concurrently with (not after) calling Wait.
+func (handle *ScanHandle) Findings() (<-chan Finding, error) {
+	return handle.findingsCh, nil
+}
+
+func (handle *ScanHandle) drainFindings(ctx context.Context) error {
+	handle.mutex.Lock()
+	offset := handle.findingOffset
+	handle.mutex.Unlock()
+
+	options := handle.service.NewListScanFindingsOptions(handle.accountID, handle.scanID)
+	options.SetOffset(offset)
+
+	result, response, err := handle.service.ListScanFindingsWithContext(ctx, options)
+	if err != nil {
+		return retryAwareError(response, err)
+	}
+	if result == nil {
+		return nil
+	}
+
+	for _, finding := range result.Findings {
+		select {
+		case handle.findingsCh <- finding:
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-handle.stop:
+			return errScanHandleClosed
+		}
+	}
+
+	handle.mutex.Lock()
+	handle.findingOffset += int64(len(result.Findings))
+	handle.mutex.Unlock()
+
+	return nil
+}
+
+func (handle *ScanHandle) closeFindingsChannel() {
+	handle.closeFindings.Do(func() {
+		close(handle.findingsCh)
+	})
+}
+
+// scanHandleState is the persisted form of a ScanHandle: enough to resume polling after a process
+// restart without losing track of which findings have already been streamed.
+type scanHandleState struct {
+	AccountID     string `json:"account_id"`
+	ScanID        string `json:"scan_id"`
+	FindingOffset int64  `json:"finding_offset"`
+}
+
+// MarshalBinary persists the scan ID and last-seen findings offset so a long scan can survive a
+// process restart. The service reference is not persisted; use RehydrateScanHandle to reconstruct a
+// usable ScanHandle from the marshaled bytes.
+func (handle *ScanHandle) MarshalBinary() ([]byte, error) {
+	handle.mutex.Lock()
+	defer handle.mutex.Unlock()
+
+	return json.Marshal(scanHandleState{
+		AccountID:     handle.accountID,
+		ScanID:        handle.scanID,
+		FindingOffset: handle.findingOffset,
+	})
+}
+
+// UnmarshalBinary restores the scan ID and last-seen findings offset from bytes produced by
+// MarshalBinary. The handle must already have a service assigned, typically via RehydrateScanHandle.
+// UnmarshalBinary alone does not start the background polling loop, so a handle restored this way
+// directly is Poll/Cancel-only: Wait blocks until its ctx is done and Findings never closes. Use
+// RehydrateScanHandle to get a handle whose Wait and Findings behave as they did before the restart.
+func (handle *ScanHandle) UnmarshalBinary(data []byte) error {
+	var state scanHandleState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return err
+	}
+
+	handle.mutex.Lock()
+	handle.accountID = state.AccountID
+	handle.scanID = state.ScanID
+	handle.findingOffset = state.FindingOffset
+	handle.mutex.Unlock()
+
+	if handle.findingsCh == nil {
+		handle.findingsCh = make(chan Finding, 64)
+	}
+	if handle.done == nil {
+		handle.done = make(chan struct{})
+	}
+
+	return nil
+}
+
+// RehydrateScanHandle reconstructs a ScanHandle from bytes previously produced by
+// ScanHandle.MarshalBinary and resumes its background polling loop under ctx, so a long-running scan's
+// Wait and Findings behave the same as before the process restart.
+func (postureManagement *PostureManagementV1) RehydrateScanHandle(ctx context.Context, data []byte) (*ScanHandle, error) {
+	handle := &ScanHandle{service: postureManagement}
+	if err := handle.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+
+	handle.ctx = ctx
+	handle.stop = make(chan struct{})
+
+	go handle.pollUntilTerminal()
+
+	return handle, nil
+}
+
+// withJitter returns d adjusted by a random +/-20% factor, to avoid many pollers retrying in lockstep.
+func withJitter(d time.Duration) time.Duration {
+	const jitter = 0.2
+	delta := (rand.Float64()*2 - 1) * jitter
+	return time.Duration(float64(d) * (1 + delta))
+}
+
+// retryAfterError signals that a poll hit a 429/503 carrying a Retry-After header, and how long to wait
+// before retrying.
+type retryAfterError struct {
+	delay time.Duration
+	cause error
+}
+
+func (e *retryAfterError) Error() string {
+	return fmt.Sprintf("retry after %s: %s", e.delay, e.cause)
+}
+
+func (e *retryAfterError) Unwrap() error {
+	return e.cause
+}
+
+// retryAwareError wraps err in a retryAfterError when response indicates a 429 or 503 with a
+// Retry-After header, so the polling loop can back off for as long as the backend asked (but never
+// less than its initial backoff).
+func retryAwareError(response *core.DetailedResponse, err error) error {
+	if response == nil || err == nil {
+		return err
+	}
+	if response.StatusCode != http.StatusTooManyRequests && response.StatusCode != http.StatusServiceUnavailable {
+		return err
+	}
+
+	retryAfter := response.Headers.Get("Retry-After")
+	if retryAfter == "" {
+		return err
+	}
+
+	if seconds, parseErr := strconv.Atoi(retryAfter); parseErr == nil {
+		return &retryAfterError{delay: time.Duration(seconds) * time.Second, cause: err}
+	}
+
+	// RFC 7231 also permits Retry-After to be an HTTP-date rather than delta-seconds.
+	if when, parseErr := http.ParseTime(retryAfter); parseErr == nil {
+		if delay := time.Until(when); delay > 0 {
+			return &retryAfterError{delay: delay, cause: err}
+		}
+		return &retryAfterError{delay: 0, cause: err}
+	}
+
+	return err
+}
+
+// GetScanSummaryOptions : The GetScanSummary options.
+type GetScanSummaryOptions struct {
+	// Your account ID.
+	AccountID *string `validate:"required"`
+
+	// The unique identifier of the scan.
+	ScanID *string `validate:"required"`
+
+	// Allows users to set headers on API requests.
+	Headers map[string]string
+}
+
+// NewGetScanSummaryOptions : Instantiate GetScanSummaryOptions.
+func (postureManagement *PostureManagementV1) NewGetScanSummaryOptions(accountID string, scanID string) *GetScanSummaryOptions {
+	return &GetScanSummaryOptions{
+		AccountID: core.StringPtr(accountID),
+		ScanID:    core.StringPtr(scanID),
+	}
+}
+
+// SetHeaders : Allow user to set Headers
+func (options *GetScanSummaryOptions) SetHeaders(param map[string]string) *GetScanSummaryOptions {
+	options.Headers = param
+	return options
+}
+
+// GetScanSummary : Get the current summary of a scan
+func (postureManagement *PostureManagementV1) GetScanSummary(getScanSummaryOptions *GetScanSummaryOptions) (result *ScanSummary, response *core.DetailedResponse, err error) {
+	return postureManagement.GetScanSummaryWithContext(context.Background(), getScanSummaryOptions)
+}
+
+// GetScanSummaryWithContext is an alternate form of GetScanSummary which supports a Context parameter.
+func (postureManagement *PostureManagementV1) GetScanSummaryWithContext(ctx context.Context, getScanSummaryOptions *GetScanSummaryOptions) (result *ScanSummary, response *core.DetailedResponse, err error) {
+	err = core.ValidateNotNil(getScanSummaryOptions, "getScanSummaryOptions cannot be nil")
+	if err != nil {
+		return
+	}
+	err = core.ValidateStruct(getScanSummaryOptions, "getScanSummaryOptions")
+	if err != nil {
+		return
+	}
+
+	pathParamsMap := map[string]string{
+		"scan_id": *getScanSummaryOptions.ScanID,
+	}
+
+	builder := core.NewRequestBuilder(core.GET)
+	builder = builder.WithContext(ctx)
+	builder.EnableGzipCompression = postureManagement.Service.GetEnableGzipCompression()
+	_, err = builder.ResolveRequestURL(postureManagement.Service.Options.URL, `/scans/{scan_id}/summary`, pathParamsMap)
+	if err != nil {
+		return
+	}
+
+	for headerName, headerValue := range getScanSummaryOptions.Headers {
+		builder.AddHeader(headerName, headerValue)
+	}
+
+	builder.AddHeader("Accept", "application/json")
+	builder.AddHeader("Account-Id", fmt.Sprint(*getScanSummaryOptions.AccountID))
+
+	request, err := builder.Build()
+	if err != nil {
+		return
+	}
+
+	var rawResponse map[string]json.RawMessage
+	response, err = postureManagement.Service.Request(request, &rawResponse)
+	if err != nil {
+		return
+	}
+	if rawResponse != nil {
+		err = core.UnmarshalPrimitive(rawResponse, "", &result)
+		if err != nil {
+			return
+		}
+		response.Result = result
+	}
+
+	return
+}
+
+// CancelScanOptions : The CancelScan options.
+type CancelScanOptions struct {
+	// Your account ID.
+	AccountID *string `validate:"required"`
+
+	// The unique identifier of the scan.
+	ScanID *string `validate:"required"`
+
+	// Allows users to set headers on API requests.
+	Headers map[string]string
+}
+
+// NewCancelScanOptions : Instantiate CancelScanOptions.
+func (postureManagement *PostureManagementV1) NewCancelScanOptions(accountID string, scanID string) *CancelScanOptions {
+	return &CancelScanOptions{
+		AccountID: core.StringPtr(accountID),
+		ScanID:    core.StringPtr(scanID),
+	}
+}
+
+// SetHeaders : Allow user to set Headers
+func (options *CancelScanOptions) SetHeaders(param map[string]string) *CancelScanOptions {
+	options.Headers = param
+	return options
+}
+
+// CancelScan : Cancel a running scan
+func (postureManagement *PostureManagementV1) CancelScan(cancelScanOptions *CancelScanOptions) (result *ScanSummary, response *core.DetailedResponse, err error) {
+	return postureManagement.CancelScanWithContext(context.Background(), cancelScanOptions)
+}
+
+// CancelScanWithContext is an alternate form of CancelScan which supports a Context parameter.
+func (postureManagement *PostureManagementV1) CancelScanWithContext(ctx context.Context, cancelScanOptions *CancelScanOptions) (result *ScanSummary, response *core.DetailedResponse, err error) {
+	err = core.ValidateNotNil(cancelScanOptions, "cancelScanOptions cannot be nil")
+	if err != nil {
+		return
+	}
+	err = core.ValidateStruct(cancelScanOptions, "cancelScanOptions")
+	if err != nil {
+		return
+	}
+
+	pathParamsMap := map[string]string{
+		"scan_id": *cancelScanOptions.ScanID,
+	}
+
+	builder := core.NewRequestBuilder(core.POST)
+	builder = builder.WithContext(ctx)
+	builder.EnableGzipCompression = postureManagement.Service.GetEnableGzipCompression()
+	_, err = builder.ResolveRequestURL(postureManagement.Service.Options.URL, `/scans/{scan_id}/cancel`, pathParamsMap)
+	if err != nil {
+		return
+	}
+
+	for headerName, headerValue := range cancelScanOptions.Headers {
+		builder.AddHeader(headerName, headerValue)
+	}
+
+	builder.AddHeader("Accept", "application/json")
+	builder.AddHeader("Account-Id", fmt.Sprint(*cancelScanOptions.AccountID))
+
+	request, err := builder.Build()
+	if err != nil {
+		return
+	}
+
+	var rawResponse map[string]json.RawMessage
+	response, err = postureManagement.Service.Request(request, &rawResponse)
+	if err != nil {
+		return
+	}
+	if rawResponse != nil {
+		err = core.UnmarshalPrimitive(rawResponse, "", &result)
+		if err != nil {
+			return
+		}
+		response.Result = result
+	}
+
+	return
+}
+
+// ListScanFindingsOptions : The ListScanFindings options.
+type ListScanFindingsOptions struct {
+	// Your account ID.
+	AccountID *string `validate:"required"`
+
+	// The unique identifier of the scan.
+	ScanID *string `validate:"required"`
+
+	// Only findings discovered after this offset are returned.
+	Offset *int64
+
+	// Allows users to set headers on API requests.
+	Headers map[string]string
+}
+
+// NewListScanFindingsOptions : Instantiate ListScanFindingsOptions.
+func (postureManagement *PostureManagementV1) NewListScanFindingsOptions(accountID string, scanID string) *ListScanFindingsOptions {
+	return &ListScanFindingsOptions{
+		AccountID: core.StringPtr(accountID),
+		ScanID:    core.StringPtr(scanID),
+	}
+}
+
+// SetOffset : Allow user to set Offset.
+func (options *ListScanFindingsOptions) SetOffset(offset int64) *ListScanFindingsOptions {
+	options.Offset = core.Int64Ptr(offset)
+	return options
+}
+
+// SetHeaders : Allow user to set Headers
+func (options *ListScanFindingsOptions) SetHeaders(param map[string]string) *ListScanFindingsOptions {
+	options.Headers = param
+	return options
+}
+
+// ScanFindingsResponse : The findings that a scan has discovered so far.
+type ScanFindingsResponse struct {
+	// The findings discovered since the requested offset.
+	Findings []Finding `json:"findings,omitempty"`
+}
+
+// ListScanFindings : List the findings a scan has discovered since a given offset
+func (postureManagement *PostureManagementV1) ListScanFindings(listScanFindingsOptions *ListScanFindingsOptions) (result *ScanFindingsResponse, response *core.DetailedResponse, err error) {
+	return postureManagement.ListScanFindingsWithContext(context.Background(), listScanFindingsOptions)
+}
+
+// ListScanFindingsWithContext is an alternate form of ListScanFindings which supports a Context parameter.
+func (postureManagement *PostureManagementV1) ListScanFindingsWithContext(ctx context.Context, listScanFindingsOptions *ListScanFindingsOptions) (result *ScanFindingsResponse, response *core.DetailedResponse, err error) {
+	err = core.ValidateNotNil(listScanFindingsOptions, "listScanFindingsOptions cannot be nil")
+	if err != nil {
+		return
+	}
+	err = core.ValidateStruct(listScanFindingsOptions, "listScanFindingsOptions")
+	if err != nil {
+		return
+	}
+
+	pathParamsMap := map[string]string{
+		"scan_id": *listScanFindingsOptions.ScanID,
+	}
+
+	builder := core.NewRequestBuilder(core.GET)
+	builder = builder.WithContext(ctx)
+	builder.EnableGzipCompression = postureManagement.Service.GetEnableGzipCompression()
+	_, err = builder.ResolveRequestURL(postureManagement.Service.Options.URL, `/scans/{scan_id}/findings`, pathParamsMap)
+	if err != nil {
+		return
+	}
+
+	for headerName, headerValue := range listScanFindingsOptions.Headers {
+		builder.AddHeader(headerName, headerValue)
+	}
+
+	builder.AddHeader("Accept", "application/json")
+	builder.AddHeader("Account-Id", fmt.Sprint(*listScanFindingsOptions.AccountID))
+	if listScanFindingsOptions.Offset != nil {
+		builder.AddQuery("offset", fmt.Sprint(*listScanFindingsOptions.Offset))
+	}
+
+	request, err := builder.Build()
+	if err != nil {
+		return
+	}
+
+	var rawResponse map[string]json.RawMessage
+	response, err = postureManagement.Service.Request(request, &rawResponse)
+	if err != nil {
+		return
+	}
+	if rawResponse != nil {
+		err = core.UnmarshalPrimitive(rawResponse, "", &result)
+		if err != nil {
+			return
+		}
+		response.Result = result
+	}
+
+	return
+}